@@ -0,0 +1,53 @@
+// Command exportverifier writes the Solidity verifyProof contract for the
+// currently persisted (or, if none exists yet, freshly generated) verifying
+// key to a file - hermetically, without a running server - so CI can solc
+// it directly instead of curling a live /api/verifier.sol endpoint.
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/consensys/gnark-crypto/ecc"
+
+	"simple_zkp_integration/internal/circuit"
+	"simple_zkp_integration/internal/keystore"
+)
+
+func main() {
+	keyDir := flag.String("key-dir", "data/keys", "directory holding the persisted proving/verifying keys")
+	out := flag.String("out", "verifier.sol", "path to write the generated Solidity verifier to")
+	flag.Parse()
+
+	store, err := keystore.NewStore(*keyDir)
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+
+	pk, vk, found, err := store.Load(ecc.BN254)
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	if !found {
+		log.Println("🔧 No persisted keys found, performing trusted setup...")
+		pk, vk, err = circuit.Setup()
+		if err != nil {
+			log.Fatalf("❌ %v", err)
+		}
+		if err := store.Save(pk, vk); err != nil {
+			log.Fatalf("❌ %v", err)
+		}
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	defer f.Close()
+
+	if err := circuit.ExportSolidityVerifier(vk, f); err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	log.Printf("✅ Wrote Solidity verifier to %s", *out)
+}
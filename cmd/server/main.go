@@ -1,14 +1,28 @@
 package main
 
 import (
+	"flag"
 	"log"
 	"net/http"
 	"simple_zkp_integration/internal/handlers"
 
 	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 func main() {
+	keyDir := flag.String("key-dir", "data/keys", "directory holding the persisted proving/verifying keys")
+	expectedVKFingerprint := flag.String("expected-vk-fingerprint", "", "fail startup unless the on-disk verifying key matches this sha256 fingerprint")
+	flag.Parse()
+
+	handlers.SetKeyConfig(*keyDir, *expectedVKFingerprint)
+
+	// Load (or generate) the proving/verifying keys and log the vk
+	// fingerprint now, at boot, so a --expected-vk-fingerprint mismatch
+	// fails startup instead of surfacing lazily on the first request.
+	if err := handlers.InitializeKeys(); err != nil {
+		log.Fatalf("❌ Key initialization failed: %v", err)
+	}
 
 	// Initialize the router
 	router := mux.NewRouter()
@@ -16,6 +30,34 @@ func main() {
 	// API routes
 	router.HandleFunc("/api/proof/generate", handlers.GenerateProof).Methods("POST")
 	router.HandleFunc("/api/proof/verify", handlers.VerifyProof).Methods("POST")
+
+	// Async proof job routes
+	router.HandleFunc("/api/proof/jobs/{id}", handlers.GetProofJob).Methods("GET")
+	router.HandleFunc("/api/proof/jobs/{id}/cancel", handlers.CancelProofJob).Methods("POST")
+	router.HandleFunc("/api/proof/jobs/{id}/wait", handlers.WaitForProofJob).Methods("GET")
+
+	// Prometheus metrics
+	router.Handle("/metrics", promhttp.Handler()).Methods("GET")
+
+	// Trusted setup ceremony routes (MPC Phase 2)
+	router.HandleFunc("/api/setup/ceremony/start", handlers.StartCeremony).Methods("POST")
+	router.HandleFunc("/api/setup/ceremony/{id}/current", handlers.GetCurrentTranscript).Methods("GET")
+	router.HandleFunc("/api/setup/ceremony/{id}/contribute", handlers.ContributeToCeremony).Methods("POST")
+	router.HandleFunc("/api/setup/ceremony/{id}/attestation", handlers.GetAttestation).Methods("GET")
+	router.HandleFunc("/api/setup/ceremony/{id}/close", handlers.CloseCeremony).Methods("POST")
+
+	router.HandleFunc("/api/vk/fingerprint", handlers.GetVKFingerprint).Methods("GET")
+	router.HandleFunc("/api/vk", handlers.GetVK).Methods("GET")
+
+	// On-chain verification support
+	router.HandleFunc("/api/verifier.sol", handlers.GetVerifierSolidity).Methods("GET")
+	router.HandleFunc("/api/proof/{id}/calldata", handlers.GetProofCalldata).Methods("POST")
+
+	// Pluggable circuit registry
+	router.HandleFunc("/api/circuits", handlers.ListCircuits).Methods("GET")
+	router.HandleFunc("/api/circuits/{name}/prove", handlers.ProveCircuit).Methods("POST")
+	router.HandleFunc("/api/circuits/{name}/verify", handlers.VerifyCircuit).Methods("POST")
+
 	router.HandleFunc("/health", handlers.HealthCheck).Methods("GET")
 
 	router.Use(corsMiddleware)
@@ -23,9 +65,25 @@ func main() {
 	// Start server
 	log.Println("🚀 ZKP API Server starting on port 8080...")
 	log.Println("📝 API Endpoints:")
-	log.Println("   POST /api/proof/generate - Generate a zero-knowledge proof")
-	log.Println("   POST /api/proof/verify   - Verify a proof")
-	log.Println("   GET  /health             - Health check")
+	log.Println("   POST /api/proof/generate              - Enqueue a zero-knowledge proof job")
+	log.Println("   GET  /api/proof/jobs/{id}              - Poll a proof job's status")
+	log.Println("   POST /api/proof/jobs/{id}/cancel       - Cancel an in-flight proof job")
+	log.Println("   GET  /api/proof/jobs/{id}/wait          - Block until a proof job finishes")
+	log.Println("   POST /api/proof/verify                - Verify a proof")
+	log.Println("   GET  /metrics                          - Prometheus metrics")
+	log.Println("   POST /api/setup/ceremony/start         - Start an MPC trusted setup ceremony")
+	log.Println("   GET  /api/setup/ceremony/{id}/current  - Fetch the current ceremony transcript")
+	log.Println("   POST /api/setup/ceremony/{id}/contribute - Submit a ceremony contribution")
+	log.Println("   GET  /api/setup/ceremony/{id}/attestation - List ceremony contributor hashes")
+	log.Println("   POST /api/setup/ceremony/{id}/close    - Close the ceremony and derive pk/vk")
+	log.Println("   GET  /api/vk/fingerprint               - SHA-256 fingerprint of the verifying key")
+	log.Println("   GET  /api/vk                           - Raw serialized verifying key")
+	log.Println("   GET  /api/verifier.sol                 - Solidity verifyProof contract source")
+	log.Println("   POST /api/proof/{id}/calldata          - Proof job re-serialized as verifyProof calldata")
+	log.Println("   GET  /api/circuits                     - List registered circuits and their schemas")
+	log.Println("   POST /api/circuits/{name}/prove        - Generate a proof for a registered circuit")
+	log.Println("   POST /api/circuits/{name}/verify       - Verify a proof for a registered circuit")
+	log.Println("   GET  /health                           - Health check")
 	
 	if err := http.ListenAndServe(":8080", router); err != nil {
 		log.Fatal(err)
@@ -1,10 +1,15 @@
 package circuit
 
 import (
-	"github.com/consensys/gnark/frontend"
+	"context"
+	"encoding/json"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
 	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/constraint/solver"
+	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/frontend/cs/r1cs"
-	"github.com/consensys/gnark-crypto/ecc"
 )
 
 // AdditionCircuit defines a simple addition circuit
@@ -14,7 +19,7 @@ type AdditionCircuit struct {
 	// Private inputs (witness) - these are secret
 	A frontend.Variable `gnark:",secret"`
 	B frontend.Variable `gnark:",secret"`
-	
+
 	// Public input - this is known to everyone
 	Sum frontend.Variable `gnark:",public"`
 }
@@ -33,21 +38,21 @@ func (circuit *AdditionCircuit) Define(api frontend.API) error {
 func Setup() (groth16.ProvingKey, groth16.VerifyingKey, error) {
 	// Create an instance of our circuit
 	var circuit AdditionCircuit
-	
+
 	// Compile the circuit into a constraint system
 	// This converts our high-level circuit into mathematical constraints
 	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit)
 	if err != nil {
 		return nil, nil, err
 	}
-	
+
 	// Run the trusted setup to generate keys
 	// In production, this would be done through a secure ceremony
 	pk, vk, err := groth16.Setup(ccs)
 	if err != nil {
 		return nil, nil, err
 	}
-	
+
 	return pk, vk, nil
 }
 
@@ -84,21 +89,74 @@ func GenerateProof(pk groth16.ProvingKey, a, b, sum int) (groth16.Proof, error)
 	return proof, nil
 }
 
+// additionInputs is the shape of the `inputs` object the registry passes to
+// DecodeAdditionAssignment / DecodeAdditionPublicAssignment.
+type additionInputs struct {
+	A   int `json:"a"`
+	B   int `json:"b"`
+	Sum int `json:"sum"`
+}
+
+// DecodeAdditionAssignment builds a full (secret + public) AdditionCircuit
+// assignment from a prove request's `inputs` object.
+func DecodeAdditionAssignment(inputs json.RawMessage) (frontend.Circuit, error) {
+	var in additionInputs
+	if err := json.Unmarshal(inputs, &in); err != nil {
+		return nil, err
+	}
+	return &AdditionCircuit{A: in.A, B: in.B, Sum: in.Sum}, nil
+}
+
+// DecodeAdditionPublicAssignment builds a public-only AdditionCircuit
+// assignment from a verify request's `inputs` object.
+func DecodeAdditionPublicAssignment(inputs json.RawMessage) (frontend.Circuit, error) {
+	var in additionInputs
+	if err := json.Unmarshal(inputs, &in); err != nil {
+		return nil, err
+	}
+	return &AdditionCircuit{Sum: in.Sum}, nil
+}
+
+// GenerateProofCtx behaves like GenerateProof but threads ctx through to the
+// solver via solver.WithContext, so groth16.Prove itself aborts as soon as
+// ctx is cancelled instead of continuing to burn CPU after its caller has
+// given up on the result.
+func GenerateProofCtx(ctx context.Context, pk groth16.ProvingKey, a, b, sum int) (groth16.Proof, error) {
+	assignment := AdditionCircuit{
+		A:   a,
+		B:   b,
+		Sum: sum,
+	}
+
+	witness, err := frontend.NewWitness(&assignment, ecc.BN254.ScalarField())
+	if err != nil {
+		return nil, err
+	}
+
+	var circuit AdditionCircuit
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit)
+	if err != nil {
+		return nil, err
+	}
+
+	return groth16.Prove(ccs, pk, witness, backend.WithSolverOptions(solver.WithContext(ctx)))
+}
+
 // VerifyProof checks if a proof is valid
 func VerifyProof(vk groth16.VerifyingKey, proof groth16.Proof, sum int) error {
 	// Create public witness (only the sum is public)
 	publicAssignment := AdditionCircuit{
 		Sum: sum,
 	}
-	
+
 	// Create public witness vector
 	publicWitness, err := frontend.NewWitness(&publicAssignment, ecc.BN254.ScalarField(), frontend.PublicOnly())
 	if err != nil {
 		return err
 	}
-	
+
 	// Verify the proof
 	// This checks the cryptographic proof without knowing a and b
 	err = groth16.Verify(proof, vk, publicWitness)
 	return err
-}
\ No newline at end of file
+}
@@ -0,0 +1,57 @@
+// internal/circuit/builtin.go
+package circuit
+
+import (
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+)
+
+// DefaultRegistry returns a Registry pre-populated with every circuit this
+// service ships: the original addition circuit on BN254/Groth16, a cubic
+// example on BN254/Groth16 (proving the Registry abstraction isn't specific
+// to one circuit shape), that same cubic circuit again on BLS12-381/Groth16
+// (proving it isn't specific to one curve either), and once more on
+// BN254/PLONK (proving it isn't specific to one backend either) -
+// Compile/GenericSetup/GenericProve/GenericVerify all dispatch on
+// entry.Curve and entry.Backend with no hard-coded assumption of either.
+func DefaultRegistry() *Registry {
+	registry := NewRegistry()
+
+	registry.Register(&Entry{
+		Name:                   "addition",
+		Curve:                  ecc.BN254,
+		Backend:                BackendGroth16,
+		NewCircuit:             func() frontend.Circuit { return &AdditionCircuit{} },
+		DecodeAssignment:       DecodeAdditionAssignment,
+		DecodePublicAssignment: DecodeAdditionPublicAssignment,
+	})
+
+	registry.Register(&Entry{
+		Name:                   "cubic",
+		Curve:                  ecc.BN254,
+		Backend:                BackendGroth16,
+		NewCircuit:             func() frontend.Circuit { return &CubicCircuit{} },
+		DecodeAssignment:       DecodeCubicAssignment,
+		DecodePublicAssignment: DecodeCubicPublicAssignment,
+	})
+
+	registry.Register(&Entry{
+		Name:                   "cubic-bls12-381",
+		Curve:                  ecc.BLS12_381,
+		Backend:                BackendGroth16,
+		NewCircuit:             func() frontend.Circuit { return &CubicCircuit{} },
+		DecodeAssignment:       DecodeCubicAssignment,
+		DecodePublicAssignment: DecodeCubicPublicAssignment,
+	})
+
+	registry.Register(&Entry{
+		Name:                   "cubic-plonk",
+		Curve:                  ecc.BN254,
+		Backend:                BackendPlonk,
+		NewCircuit:             func() frontend.Circuit { return &CubicCircuit{} },
+		DecodeAssignment:       DecodeCubicAssignment,
+		DecodePublicAssignment: DecodeCubicPublicAssignment,
+	})
+
+	return registry
+}
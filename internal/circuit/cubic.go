@@ -0,0 +1,50 @@
+// internal/circuit/cubic.go
+package circuit
+
+import (
+	"encoding/json"
+
+	"github.com/consensys/gnark/frontend"
+)
+
+// CubicCircuit proves: "I know x such that x^3 + x + 5 = y" without
+// revealing x. It exists as a second registry entry alongside
+// AdditionCircuit, to prove the circuit.Registry abstraction holds for more
+// than one shape of circuit.
+type CubicCircuit struct {
+	X frontend.Variable `gnark:",secret"`
+	Y frontend.Variable `gnark:",public"`
+}
+
+// Define declares the constraint x^3 + x + 5 == y.
+func (circuit *CubicCircuit) Define(api frontend.API) error {
+	x3 := api.Mul(circuit.X, circuit.X, circuit.X)
+	api.AssertIsEqual(api.Add(x3, circuit.X, 5), circuit.Y)
+	return nil
+}
+
+// cubicInputs is the shape of the `inputs` object for the cubic circuit.
+type cubicInputs struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// DecodeCubicAssignment builds a full (secret + public) CubicCircuit
+// assignment from a prove request's `inputs` object.
+func DecodeCubicAssignment(inputs json.RawMessage) (frontend.Circuit, error) {
+	var in cubicInputs
+	if err := json.Unmarshal(inputs, &in); err != nil {
+		return nil, err
+	}
+	return &CubicCircuit{X: in.X, Y: in.Y}, nil
+}
+
+// DecodeCubicPublicAssignment builds a public-only CubicCircuit assignment
+// from a verify request's `inputs` object.
+func DecodeCubicPublicAssignment(inputs json.RawMessage) (frontend.Circuit, error) {
+	var in cubicInputs
+	if err := json.Unmarshal(inputs, &in); err != nil {
+		return nil, err
+	}
+	return &CubicCircuit{Y: in.Y}, nil
+}
@@ -0,0 +1,175 @@
+// internal/circuit/generic.go
+package circuit
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/plonk"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/frontend/cs/scs"
+	"github.com/consensys/gnark/test/unsafekzg"
+)
+
+// ProvingKey, VerifyingKey and Proof are the backend-erased types the
+// registry-aware functions below hand back: groth16.ProvingKey/
+// VerifyingKey/Proof and plonk.ProvingKey/VerifyingKey/Proof are distinct
+// concrete types with no shared interface in gnark, but both implement
+// WriteTo/ReadFrom, which is all a caller needs to persist or transmit one
+// without caring which backend produced it.
+type (
+	ProvingKey interface {
+		io.WriterTo
+		io.ReaderFrom
+	}
+	VerifyingKey interface {
+		io.WriterTo
+		io.ReaderFrom
+	}
+	Proof interface {
+		io.WriterTo
+		io.ReaderFrom
+	}
+)
+
+// Compile builds the constraint system for a registered circuit, dispatching
+// on both entry.Curve (frontend.Compile takes entry.Curve.ScalarField()
+// directly) and entry.Backend (Groth16 circuits compile to R1CS via
+// r1cs.NewBuilder, PLONK circuits to sparse-R1CS via scs.NewBuilder). This is
+// how DefaultRegistry proves the same CubicCircuit on BN254 and BLS12-381
+// with Groth16, and on BN254 with PLONK, from one Compile implementation.
+func Compile(entry *Entry) (constraint.ConstraintSystem, error) {
+	switch entry.Backend {
+	case BackendGroth16:
+		return frontend.Compile(entry.Curve.ScalarField(), r1cs.NewBuilder, entry.NewCircuit())
+	case BackendPlonk:
+		return frontend.Compile(entry.Curve.ScalarField(), scs.NewBuilder, entry.NewCircuit())
+	default:
+		return nil, fmt.Errorf("backend %q is not implemented", entry.Backend)
+	}
+}
+
+// GenericSetup runs the trusted setup for a compiled circuit. For PLONK this
+// derives the required KZG SRS via unsafekzg, which - as its name warns -
+// samples its own toxic waste in-process and is fit for this registry's
+// demo/dev use, not for a production PLONK deployment; Groth16 circuits
+// still go through the MPC ceremony in the mpcsetup package instead.
+func GenericSetup(entry *Entry, ccs constraint.ConstraintSystem) (ProvingKey, VerifyingKey, error) {
+	switch entry.Backend {
+	case BackendGroth16:
+		return groth16.Setup(ccs)
+	case BackendPlonk:
+		srs, srsLagrange, err := unsafekzg.NewSRS(ccs)
+		if err != nil {
+			return nil, nil, fmt.Errorf("generating KZG SRS: %w", err)
+		}
+		return plonk.Setup(ccs, srs, srsLagrange)
+	default:
+		return nil, nil, fmt.Errorf("backend %q is not implemented", entry.Backend)
+	}
+}
+
+// GenericProve generates a proof for assignment against a compiled circuit
+// and proving key, dispatching on the entry's curve and backend. pk must be
+// the concrete groth16.ProvingKey or plonk.ProvingKey GenericSetup returned
+// for this same entry.
+func GenericProve(entry *Entry, ccs constraint.ConstraintSystem, pk ProvingKey, assignment frontend.Circuit) (Proof, error) {
+	witness, err := frontend.NewWitness(assignment, entry.Curve.ScalarField())
+	if err != nil {
+		return nil, err
+	}
+
+	switch entry.Backend {
+	case BackendGroth16:
+		groth16PK, ok := pk.(groth16.ProvingKey)
+		if !ok {
+			return nil, fmt.Errorf("expected a groth16.ProvingKey for backend %q, got %T", entry.Backend, pk)
+		}
+		return groth16.Prove(ccs, groth16PK, witness)
+	case BackendPlonk:
+		plonkPK, ok := pk.(plonk.ProvingKey)
+		if !ok {
+			return nil, fmt.Errorf("expected a plonk.ProvingKey for backend %q, got %T", entry.Backend, pk)
+		}
+		return plonk.Prove(ccs, plonkPK, witness)
+	default:
+		return nil, fmt.Errorf("backend %q is not implemented", entry.Backend)
+	}
+}
+
+// GenericVerify checks proof against a public assignment, dispatching on the
+// entry's curve and backend. vk and proof must be the concrete groth16 or
+// plonk types matching entry.Backend.
+func GenericVerify(entry *Entry, vk VerifyingKey, proof Proof, publicAssignment frontend.Circuit) error {
+	publicWitness, err := frontend.NewWitness(publicAssignment, entry.Curve.ScalarField(), frontend.PublicOnly())
+	if err != nil {
+		return err
+	}
+
+	switch entry.Backend {
+	case BackendGroth16:
+		groth16VK, ok := vk.(groth16.VerifyingKey)
+		if !ok {
+			return fmt.Errorf("expected a groth16.VerifyingKey for backend %q, got %T", entry.Backend, vk)
+		}
+		groth16Proof, ok := proof.(groth16.Proof)
+		if !ok {
+			return fmt.Errorf("expected a groth16.Proof for backend %q, got %T", entry.Backend, proof)
+		}
+		return groth16.Verify(groth16Proof, groth16VK, publicWitness)
+	case BackendPlonk:
+		plonkVK, ok := vk.(plonk.VerifyingKey)
+		if !ok {
+			return fmt.Errorf("expected a plonk.VerifyingKey for backend %q, got %T", entry.Backend, vk)
+		}
+		plonkProof, ok := proof.(plonk.Proof)
+		if !ok {
+			return fmt.Errorf("expected a plonk.Proof for backend %q, got %T", entry.Backend, proof)
+		}
+		return plonk.Verify(plonkProof, plonkVK, publicWitness)
+	default:
+		return fmt.Errorf("backend %q is not implemented", entry.Backend)
+	}
+}
+
+// NewProof returns an empty proof for the entry's curve and backend, ready
+// for proof.ReadFrom to deserialize into.
+func NewProof(entry *Entry) Proof {
+	switch entry.Backend {
+	case BackendGroth16:
+		return groth16.NewProof(entry.Curve)
+	case BackendPlonk:
+		return plonk.NewProof(entry.Curve)
+	default:
+		return nil
+	}
+}
+
+// NewProvingKey and NewVerifyingKey return blank, backend-and-curve-
+// appropriate key values ready for ReadFrom to deserialize into - the
+// factories keystore.Store.LoadGeneric needs to load a persisted key
+// without keystore itself knowing about groth16 or plonk.
+func NewProvingKey(entry *Entry) ProvingKey {
+	switch entry.Backend {
+	case BackendGroth16:
+		return groth16.NewProvingKey(entry.Curve)
+	case BackendPlonk:
+		return plonk.NewProvingKey(entry.Curve)
+	default:
+		return nil
+	}
+}
+
+func NewVerifyingKey(entry *Entry) VerifyingKey {
+	switch entry.Backend {
+	case BackendGroth16:
+		return groth16.NewVerifyingKey(entry.Curve)
+	case BackendPlonk:
+		return plonk.NewVerifyingKey(entry.Curve)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,275 @@
+// internal/circuit/mpcsetup/ceremony.go
+//
+// This package is written against the backend/groth16/bn254/mpcsetup API as
+// it existed in gnark v0.12 (InitPhase1/InitPhase2/VerifyPhase2/ExtractKeys).
+// That API was reshuffled in v0.13, so go.mod must pin gnark to a v0.12.x
+// release for this package to build.
+package mpcsetup
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/bits"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/groth16/bn254/mpcsetup"
+	cs_bn254 "github.com/consensys/gnark/constraint/bn254"
+)
+
+// ContributionRecord is the public, auditable record of a single participant's
+// contribution to a Phase 2 ceremony. Only the hash of the transcript the
+// participant produced is kept - the transcript itself is persisted separately.
+type ContributionRecord struct {
+	Index int    `json:"index"`
+	Hash  string `json:"hash"` // hex-encoded SHA-256 of the contributed transcript
+}
+
+// Ceremony coordinates a multi-party Phase 2 trusted setup for a single
+// compiled circuit. Each accepted contribution is appended to an ordered,
+// verifiable chain of transcripts and persisted to disk so the ceremony can
+// be audited, or resumed, after a coordinator restart.
+type Ceremony struct {
+	mu sync.Mutex
+
+	id  string
+	dir string
+	ccs *cs_bn254.R1CS
+
+	phase1       mpcsetup.Phase1
+	evals        mpcsetup.Phase2Evaluations
+	transcripts  []mpcsetup.Phase2
+	contributors []ContributionRecord
+
+	closed bool
+}
+
+// NewCeremony starts a fresh ceremony for ccs and writes the initial Phase 2
+// transcript to disk. If powersOfTau is non-empty it is treated as a
+// previously generated Phase 1 SRS (a Powers-of-Tau file); otherwise a local
+// Phase 1 is initialized and contributed to once so the ceremony has a
+// starting point.
+func NewCeremony(id string, ccs *cs_bn254.R1CS, baseDir string, powersOfTau []byte) (*Ceremony, error) {
+	var phase1 mpcsetup.Phase1
+	if len(powersOfTau) > 0 {
+		if _, err := phase1.ReadFrom(bytes.NewReader(powersOfTau)); err != nil {
+			return nil, fmt.Errorf("reading powers-of-tau SRS: %w", err)
+		}
+	} else {
+		phase1 = mpcsetup.InitPhase1(powerOf2Exponent(ccs.GetNbConstraints()))
+		phase1.Contribute()
+	}
+
+	phase2, evals := mpcsetup.InitPhase2(ccs, &phase1)
+
+	c := &Ceremony{
+		id:          id,
+		dir:         filepath.Join(baseDir, id),
+		ccs:         ccs,
+		phase1:      phase1,
+		evals:       evals,
+		transcripts: []mpcsetup.Phase2{phase2},
+	}
+
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating ceremony directory: %w", err)
+	}
+	if err := c.persistPhase1(); err != nil {
+		return nil, err
+	}
+	if err := c.persist(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// LoadCeremony reconstructs a ceremony from the transcripts persisted to
+// baseDir/id, so a coordinator restart does not lose ceremony progress. This
+// restores phase1 from phase1.bin and then recomputes evals by re-running
+// InitPhase2 against (ccs, phase1) - Phase2Evaluations has no serialization
+// of its own, but it is a pure function of those two inputs, so recomputing
+// it here reconstructs exactly what NewCeremony held in memory without
+// needing to persist it separately. The Phase2 InitPhase2 also returns here
+// is discarded; the ceremony's real current transcript is whichever
+// transcript-N.bin was last written, already loaded into c.transcripts above.
+func LoadCeremony(id, baseDir string, ccs *cs_bn254.R1CS) (*Ceremony, error) {
+	dir := filepath.Join(baseDir, id)
+	paths, err := filepath.Glob(filepath.Join(dir, "transcript-*.bin"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no ceremony found with id %q", id)
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		return transcriptIndex(paths[i]) < transcriptIndex(paths[j])
+	})
+
+	c := &Ceremony{id: id, dir: dir, ccs: ccs}
+	for _, p := range paths {
+		raw, err := os.ReadFile(p)
+		if err != nil {
+			return nil, err
+		}
+		var phase2 mpcsetup.Phase2
+		if _, err := phase2.ReadFrom(bytes.NewReader(raw)); err != nil {
+			return nil, fmt.Errorf("decoding %s: %w", p, err)
+		}
+		c.transcripts = append(c.transcripts, phase2)
+	}
+
+	phase1Raw, err := os.ReadFile(filepath.Join(dir, "phase1.bin"))
+	if err != nil {
+		return nil, fmt.Errorf("reading phase1.bin: %w", err)
+	}
+	if _, err := c.phase1.ReadFrom(bytes.NewReader(phase1Raw)); err != nil {
+		return nil, fmt.Errorf("decoding phase1.bin: %w", err)
+	}
+	_, c.evals = mpcsetup.InitPhase2(ccs, &c.phase1)
+
+	if raw, err := os.ReadFile(filepath.Join(dir, "contributors.json")); err == nil {
+		if err := json.Unmarshal(raw, &c.contributors); err != nil {
+			return nil, fmt.Errorf("decoding contributors.json: %w", err)
+		}
+	}
+	return c, nil
+}
+
+// persistPhase1 writes the ceremony's Phase 1 SRS to disk once, at ceremony
+// creation, so a reloaded ceremony (see LoadCeremony) has a phase1 to
+// recompute evals from and to pass to ExtractKeys on Close - without it,
+// Close on a resumed ceremony would derive keys from a zero-value Phase1.
+func (c *Ceremony) persistPhase1() error {
+	buf := new(bytes.Buffer)
+	if _, err := c.phase1.WriteTo(buf); err != nil {
+		return fmt.Errorf("serializing phase1 SRS: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(c.dir, "phase1.bin"), buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("writing phase1.bin: %w", err)
+	}
+	return nil
+}
+
+// powerOf2Exponent returns the smallest k such that 2^k >= n, the domain
+// size InitPhase1 expects for a circuit with n constraints.
+func powerOf2Exponent(n int) int {
+	if n <= 1 {
+		return 1
+	}
+	return bits.Len(uint(n - 1))
+}
+
+func transcriptIndex(path string) int {
+	base := strings.TrimSuffix(filepath.Base(path), ".bin")
+	n, _ := strconv.Atoi(strings.TrimPrefix(base, "transcript-"))
+	return n
+}
+
+// CurrentTranscript returns the latest accepted Phase 2 transcript, which a
+// participant fetches, contributes to locally, and posts back.
+func (c *Ceremony) CurrentTranscript() ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return serialize(c.transcripts[len(c.transcripts)-1])
+}
+
+// SubmitContribution verifies a participant-contributed transcript against
+// the previous one (the pairing equations checked by mpcsetup's Verify) and,
+// if it is valid, appends it to the ceremony and persists it to disk.
+func (c *Ceremony) SubmitContribution(transcript []byte) (ContributionRecord, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return ContributionRecord{}, errors.New("ceremony is closed to new contributions")
+	}
+
+	var next mpcsetup.Phase2
+	if _, err := next.ReadFrom(bytes.NewReader(transcript)); err != nil {
+		return ContributionRecord{}, fmt.Errorf("decoding contribution: %w", err)
+	}
+
+	prev := c.transcripts[len(c.transcripts)-1]
+	// VerifyPhase2 takes the previous transcript first and the new
+	// contribution second - it checks that next is a valid delta-update on
+	// top of prev, not the other way around.
+	if err := mpcsetup.VerifyPhase2(&prev, &next); err != nil {
+		return ContributionRecord{}, fmt.Errorf("contribution failed verification: %w", err)
+	}
+
+	hash := sha256.Sum256(transcript)
+	record := ContributionRecord{Index: len(c.transcripts), Hash: hex.EncodeToString(hash[:])}
+
+	c.transcripts = append(c.transcripts, next)
+	c.contributors = append(c.contributors, record)
+
+	if err := c.persist(); err != nil {
+		return ContributionRecord{}, err
+	}
+	return record, nil
+}
+
+// Close ends the ceremony and derives the final proving/verifying keys from
+// the last accepted transcript. A ceremony with no contributions cannot be
+// closed, since its SRS would still depend solely on the coordinator.
+func (c *Ceremony) Close() (groth16.ProvingKey, groth16.VerifyingKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.contributors) == 0 {
+		return nil, nil, errors.New("cannot close a ceremony with zero contributions")
+	}
+
+	last := c.transcripts[len(c.transcripts)-1]
+	pk, vk := mpcsetup.ExtractKeys(&c.phase1, &last, &c.evals, c.ccs.GetNbConstraints())
+	c.closed = true
+	return pk, vk, nil
+}
+
+// Attestation returns the ordered list of contributor transcript hashes so
+// anyone can audit who took part in the ceremony and in what order.
+func (c *Ceremony) Attestation() []ContributionRecord {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make([]ContributionRecord, len(c.contributors))
+	copy(out, c.contributors)
+	return out
+}
+
+func (c *Ceremony) persist() error {
+	index := len(c.transcripts) - 1
+	buf, err := serialize(c.transcripts[index])
+	if err != nil {
+		return err
+	}
+	transcriptPath := filepath.Join(c.dir, fmt.Sprintf("transcript-%d.bin", index))
+	if err := os.WriteFile(transcriptPath, buf, 0o644); err != nil {
+		return fmt.Errorf("writing transcript: %w", err)
+	}
+
+	meta, err := json.MarshalIndent(c.contributors, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(c.dir, "contributors.json"), meta, 0o644); err != nil {
+		return fmt.Errorf("writing contributors.json: %w", err)
+	}
+	return nil
+}
+
+func serialize(p mpcsetup.Phase2) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if _, err := p.WriteTo(buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
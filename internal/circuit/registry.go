@@ -0,0 +1,137 @@
+// internal/circuit/registry.go
+package circuit
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+)
+
+// Backend is the proving system a registered circuit runs on. Both values
+// are real: Compile/GenericSetup/GenericProve/GenericVerify in generic.go
+// dispatch on Backend to the matching gnark backend package (groth16 or
+// plonk) and constraint-system builder (r1cs or scs).
+type Backend string
+
+const (
+	BackendGroth16 Backend = "groth16"
+	BackendPlonk   Backend = "plonk"
+)
+
+// DecodeAssignmentFunc turns the `inputs` object of a prove/verify request
+// into a fully (or, for verification, publicly) populated circuit assignment.
+type DecodeAssignmentFunc func(inputs json.RawMessage) (frontend.Circuit, error)
+
+// Entry is everything the registry needs to compile, set up, prove and
+// verify one circuit, independent of which curve or backend it targets.
+type Entry struct {
+	Name    string
+	Curve   ecc.ID
+	Backend Backend
+
+	// NewCircuit returns a fresh, zero-valued circuit instance - used both to
+	// compile the constraint system and to derive the public/secret schema.
+	NewCircuit func() frontend.Circuit
+
+	// DecodeAssignment builds a full (secret + public) assignment for proving.
+	DecodeAssignment DecodeAssignmentFunc
+	// DecodePublicAssignment builds a public-only assignment for verifying.
+	DecodePublicAssignment DecodeAssignmentFunc
+}
+
+// FieldSchema describes one field of a circuit's assignment struct, derived
+// from its `gnark:"...,public|secret"` tag, for GET /api/circuits.
+type FieldSchema struct {
+	Name       string `json:"name"`
+	Visibility string `json:"visibility"` // "public" or "secret"
+}
+
+// Metadata is the public description of a registered circuit.
+type Metadata struct {
+	Name    string        `json:"name"`
+	Curve   string        `json:"curve"`
+	Backend Backend       `json:"backend"`
+	Inputs  []FieldSchema `json:"inputs"`
+}
+
+// Registry holds every circuit the service knows how to prove and verify.
+type Registry struct {
+	mu      sync.RWMutex
+	entries map[string]*Entry
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{entries: make(map[string]*Entry)}
+}
+
+// Register adds entry to the registry, keyed by its Name.
+func (r *Registry) Register(entry *Entry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries[entry.Name] = entry
+}
+
+// Get looks up a registered circuit by name.
+func (r *Registry) Get(name string) (*Entry, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	entry, ok := r.entries[name]
+	return entry, ok
+}
+
+// List returns metadata for every registered circuit, sorted by name.
+func (r *Registry) List() []Metadata {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := make([]Metadata, 0, len(r.entries))
+	for _, entry := range r.entries {
+		out = append(out, Metadata{
+			Name:    entry.Name,
+			Curve:   entry.Curve.String(),
+			Backend: entry.Backend,
+			Inputs:  schemaOf(entry.NewCircuit()),
+		})
+	}
+	return out
+}
+
+// schemaOf reflects over a circuit's assignment struct and classifies each
+// field as public or secret from its `gnark` tag.
+func schemaOf(c frontend.Circuit) []FieldSchema {
+	v := reflect.ValueOf(c)
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	t := v.Type()
+
+	var fields []FieldSchema
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("gnark")
+		if tag == "" {
+			continue
+		}
+
+		visibility := "secret"
+		for _, part := range strings.Split(tag, ",") {
+			if part == "public" || part == "secret" {
+				visibility = part
+			}
+		}
+		fields = append(fields, FieldSchema{Name: field.Name, Visibility: visibility})
+	}
+	return fields
+}
+
+// ErrUnknownCircuit is returned when a prove/verify request names a circuit
+// that was never registered.
+func ErrUnknownCircuit(name string) error {
+	return fmt.Errorf("unknown circuit %q", name)
+}
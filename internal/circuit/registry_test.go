@@ -0,0 +1,53 @@
+// internal/circuit/registry_test.go
+package circuit
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/consensys/gnark/frontend"
+)
+
+// TestSchemaOfClassifiesVisibility checks that schemaOf reads the
+// `gnark:",public"`/`",secret"` tag on each field rather than guessing, and
+// skips fields with no gnark tag at all.
+func TestSchemaOfClassifiesVisibility(t *testing.T) {
+	got := schemaOf(&AdditionCircuit{})
+	want := []FieldSchema{
+		{Name: "A", Visibility: "secret"},
+		{Name: "B", Visibility: "secret"},
+		{Name: "Sum", Visibility: "public"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("schemaOf(AdditionCircuit) = %+v, want %+v", got, want)
+	}
+}
+
+// TestSchemaOfDefaultsUntaggedSecretAndSkipsBareFields checks the two edge
+// cases in schemaOf's tag parsing: a `gnark:""` tag with no explicit
+// visibility defaults to secret, and a field with no gnark tag at all is
+// omitted from the schema rather than misreported.
+func TestSchemaOfDefaultsUntaggedSecretAndSkipsBareFields(t *testing.T) {
+	got := schemaOf(&mixedVisibilityCircuit{})
+	want := []FieldSchema{
+		{Name: "NoVisibility", Visibility: "secret"},
+		{Name: "Public", Visibility: "public"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("schemaOf(mixedVisibilityCircuit) = %+v, want %+v", got, want)
+	}
+}
+
+// mixedVisibilityCircuit exists only to exercise schemaOf's tag-parsing edge
+// cases: a field with no gnark tag at all (Untagged) and one with a bare
+// `gnark:""` tag that names no visibility (NoVisibility, which must default
+// to secret). It is never compiled or proved.
+type mixedVisibilityCircuit struct {
+	Untagged     int
+	NoVisibility frontend.Variable `gnark:""`
+	Public       frontend.Variable `gnark:",public"`
+}
+
+func (c *mixedVisibilityCircuit) Define(api frontend.API) error {
+	return nil
+}
@@ -0,0 +1,45 @@
+// internal/circuit/solidity.go
+package circuit
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark/backend/groth16"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+)
+
+// ExportSolidityVerifier writes a Solidity verifyProof contract for vk to w,
+// so proofs produced by this service can also be checked on-chain.
+func ExportSolidityVerifier(vk groth16.VerifyingKey, w io.Writer) error {
+	return vk.ExportSolidity(w)
+}
+
+// Calldata is the (a, b, c, input) tuple the generated verifyProof function
+// expects, in the field-element ordering EVM pairing precompiles use.
+type Calldata struct {
+	A     [2]*big.Int    `json:"a"`
+	B     [2][2]*big.Int `json:"b"`
+	C     [2]*big.Int    `json:"c"`
+	Input []*big.Int     `json:"input"`
+}
+
+// ProofToCalldata re-serializes a Groth16 BN254 proof into the calldata tuple
+// the Solidity verifier generated by ExportSolidityVerifier expects.
+func ProofToCalldata(proof groth16.Proof, publicInputs []*big.Int) (Calldata, error) {
+	p, ok := proof.(*groth16_bn254.Proof)
+	if !ok {
+		return Calldata{}, fmt.Errorf("expected a BN254 groth16 proof, got %T", proof)
+	}
+
+	return Calldata{
+		A: [2]*big.Int{p.Ar.X.BigInt(new(big.Int)), p.Ar.Y.BigInt(new(big.Int))},
+		B: [2][2]*big.Int{
+			{p.Bs.X.A1.BigInt(new(big.Int)), p.Bs.X.A0.BigInt(new(big.Int))},
+			{p.Bs.Y.A1.BigInt(new(big.Int)), p.Bs.Y.A0.BigInt(new(big.Int))},
+		},
+		C:     [2]*big.Int{p.Krs.X.BigInt(new(big.Int)), p.Krs.Y.BigInt(new(big.Int))},
+		Input: publicInputs,
+	}, nil
+}
@@ -0,0 +1,72 @@
+// internal/circuit/solidity_test.go
+package circuit
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+)
+
+// TestProofToCalldataFieldOrdering checks that ProofToCalldata copies each
+// proof element into the slot the generated Solidity verifyProof expects,
+// rather than e.g. swapping A/B/C or the A0/A1 components of a G2 point.
+func TestProofToCalldataFieldOrdering(t *testing.T) {
+	pk, _, err := Setup()
+	if err != nil {
+		t.Fatalf("Setup: %v", err)
+	}
+
+	proof, err := GenerateProof(pk, 2, 3, 5)
+	if err != nil {
+		t.Fatalf("GenerateProof: %v", err)
+	}
+
+	calldata, err := ProofToCalldata(proof, []*big.Int{big.NewInt(5)})
+	if err != nil {
+		t.Fatalf("ProofToCalldata: %v", err)
+	}
+
+	p := proof.(*groth16_bn254.Proof)
+
+	wantA := [2]*big.Int{p.Ar.X.BigInt(new(big.Int)), p.Ar.Y.BigInt(new(big.Int))}
+	for i := range wantA {
+		if calldata.A[i].Cmp(wantA[i]) != 0 {
+			t.Errorf("A[%d] = %s, want %s", i, calldata.A[i], wantA[i])
+		}
+	}
+
+	wantB := [2][2]*big.Int{
+		{p.Bs.X.A1.BigInt(new(big.Int)), p.Bs.X.A0.BigInt(new(big.Int))},
+		{p.Bs.Y.A1.BigInt(new(big.Int)), p.Bs.Y.A0.BigInt(new(big.Int))},
+	}
+	for i := range wantB {
+		for j := range wantB[i] {
+			if calldata.B[i][j].Cmp(wantB[i][j]) != 0 {
+				t.Errorf("B[%d][%d] = %s, want %s", i, j, calldata.B[i][j], wantB[i][j])
+			}
+		}
+	}
+
+	wantC := [2]*big.Int{p.Krs.X.BigInt(new(big.Int)), p.Krs.Y.BigInt(new(big.Int))}
+	for i := range wantC {
+		if calldata.C[i].Cmp(wantC[i]) != 0 {
+			t.Errorf("C[%d] = %s, want %s", i, calldata.C[i], wantC[i])
+		}
+	}
+
+	if len(calldata.Input) != 1 || calldata.Input[0].Cmp(big.NewInt(5)) != 0 {
+		t.Errorf("Input = %v, want [5]", calldata.Input)
+	}
+}
+
+// TestProofToCalldataRejectsOtherCurves checks that a non-BN254 proof is
+// rejected instead of silently being reinterpreted as BN254 field elements.
+func TestProofToCalldataRejectsOtherCurves(t *testing.T) {
+	proof := groth16.NewProof(ecc.BLS12_381)
+	if _, err := ProofToCalldata(proof, nil); err == nil {
+		t.Fatal("expected an error for a non-BN254 proof, got nil")
+	}
+}
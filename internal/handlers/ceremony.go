@@ -0,0 +1,206 @@
+// internal/handlers/ceremony.go
+package handlers
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"sync/atomic"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	cs_bn254 "github.com/consensys/gnark/constraint/bn254"
+	"github.com/gorilla/mux"
+
+	"simple_zkp_integration/internal/circuit"
+	"simple_zkp_integration/internal/circuit/mpcsetup"
+)
+
+// ceremonyDir is where every ceremony's transcripts are persisted so they can
+// be audited, or resumed, after a coordinator restart.
+const ceremonyDir = "data/ceremonies"
+
+var (
+	ceremonies      = make(map[string]*mpcsetup.Ceremony)
+	ceremoniesMu    sync.Mutex
+	ceremonyCounter uint64
+)
+
+// StartCeremonyRequest optionally carries a previously generated Phase 1
+// Powers-of-Tau SRS. When absent, the coordinator initializes its own Phase 1
+// for the ceremony.
+type startCeremonyRequest struct {
+	PowersOfTau []byte `json:"powers_of_tau,omitempty"` // base64-decoded by encoding/json
+}
+
+type ceremonyResponse struct {
+	CeremonyID string `json:"ceremony_id"`
+	Transcript []byte `json:"transcript"`
+}
+
+// StartCeremony handles POST /api/setup/ceremony/start
+func StartCeremony(w http.ResponseWriter, r *http.Request) {
+	var req startCeremonyRequest
+	if r.Body != nil && r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+	}
+
+	ccs, err := compileAdditionCircuit()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to compile circuit: "+err.Error())
+		return
+	}
+
+	id := fmt.Sprintf("ceremony-%d", atomic.AddUint64(&ceremonyCounter, 1))
+	ceremony, err := mpcsetup.NewCeremony(id, ccs, ceremonyDir, req.PowersOfTau)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to start ceremony: "+err.Error())
+		return
+	}
+
+	ceremoniesMu.Lock()
+	ceremonies[id] = ceremony
+	ceremoniesMu.Unlock()
+
+	transcript, err := ceremony.CurrentTranscript()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to serialize transcript: "+err.Error())
+		return
+	}
+
+	log.Printf("🕯️  Ceremony %s started", id)
+	respondWithJSON(w, http.StatusOK, ceremonyResponse{CeremonyID: id, Transcript: transcript})
+}
+
+// GetCurrentTranscript handles GET /api/setup/ceremony/{id}/current
+func GetCurrentTranscript(w http.ResponseWriter, r *http.Request) {
+	ceremony, ok := lookupCeremony(w, r)
+	if !ok {
+		return
+	}
+	transcript, err := ceremony.CurrentTranscript()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to serialize transcript: "+err.Error())
+		return
+	}
+	respondWithJSON(w, http.StatusOK, ceremonyResponse{CeremonyID: mux.Vars(r)["id"], Transcript: transcript})
+}
+
+type contributeRequest struct {
+	Transcript []byte `json:"transcript"`
+}
+
+// ContributeToCeremony handles POST /api/setup/ceremony/{id}/contribute
+func ContributeToCeremony(w http.ResponseWriter, r *http.Request) {
+	ceremony, ok := lookupCeremony(w, r)
+	if !ok {
+		return
+	}
+
+	var req contributeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	record, err := ceremony.SubmitContribution(req.Transcript)
+	if err != nil {
+		log.Printf("❌ Contribution rejected: %v", err)
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	log.Printf("✅ Accepted contribution #%d (%s)", record.Index, record.Hash)
+	respondWithJSON(w, http.StatusOK, record)
+}
+
+// GetAttestation handles GET /api/setup/ceremony/{id}/attestation
+func GetAttestation(w http.ResponseWriter, r *http.Request) {
+	ceremony, ok := lookupCeremony(w, r)
+	if !ok {
+		return
+	}
+	respondWithJSON(w, http.StatusOK, ceremony.Attestation())
+}
+
+// CloseCeremony handles POST /api/setup/ceremony/{id}/close
+func CloseCeremony(w http.ResponseWriter, r *http.Request) {
+	ceremony, ok := lookupCeremony(w, r)
+	if !ok {
+		return
+	}
+
+	pk, vk, err := ceremony.Close()
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	pkBytes, vkBytes, err := serializeKeys(pk, vk)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to serialize derived keys: "+err.Error())
+		return
+	}
+
+	log.Printf("🔒 Ceremony %s closed, keys derived from %d contributions", mux.Vars(r)["id"], len(ceremony.Attestation()))
+	respondWithJSON(w, http.StatusOK, map[string]string{
+		"proving_key":   base64.StdEncoding.EncodeToString(pkBytes),
+		"verifying_key": base64.StdEncoding.EncodeToString(vkBytes),
+	})
+}
+
+func lookupCeremony(w http.ResponseWriter, r *http.Request) (*mpcsetup.Ceremony, bool) {
+	id := mux.Vars(r)["id"]
+
+	ceremoniesMu.Lock()
+	ceremony, found := ceremonies[id]
+	ceremoniesMu.Unlock()
+	if found {
+		return ceremony, true
+	}
+
+	ccs, err := compileAdditionCircuit()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to compile circuit: "+err.Error())
+		return nil, false
+	}
+	ceremony, err = mpcsetup.LoadCeremony(id, ceremonyDir, ccs)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "Unknown ceremony: "+id)
+		return nil, false
+	}
+
+	ceremoniesMu.Lock()
+	ceremonies[id] = ceremony
+	ceremoniesMu.Unlock()
+	return ceremony, true
+}
+
+func serializeKeys(pk groth16.ProvingKey, vk groth16.VerifyingKey) (pkBytes, vkBytes []byte, err error) {
+	var pkBuf, vkBuf bytes.Buffer
+	if _, err := pk.WriteTo(&pkBuf); err != nil {
+		return nil, nil, err
+	}
+	if _, err := vk.WriteTo(&vkBuf); err != nil {
+		return nil, nil, err
+	}
+	return pkBuf.Bytes(), vkBuf.Bytes(), nil
+}
+
+func compileAdditionCircuit() (*cs_bn254.R1CS, error) {
+	var additionCircuit circuit.AdditionCircuit
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &additionCircuit)
+	if err != nil {
+		return nil, err
+	}
+	return ccs.(*cs_bn254.R1CS), nil
+}
@@ -0,0 +1,203 @@
+// internal/handlers/circuits.go
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"path/filepath"
+	"sync"
+
+	"github.com/consensys/gnark/constraint"
+	"github.com/gorilla/mux"
+
+	"simple_zkp_integration/internal/circuit"
+	"simple_zkp_integration/internal/keystore"
+)
+
+// registry holds every circuit exposed over /api/circuits/{name}/....
+var registry = circuit.DefaultRegistry()
+
+var (
+	circuitStates   = make(map[string]*circuitKeys)
+	circuitStatesMu sync.Mutex
+)
+
+// circuitKeys is the compiled circuit plus its proving/verifying keys, set up
+// lazily and cached for the life of the process - the registry-generic
+// analogue of the package-level provingKey/verifyingKey in proof.go.
+type circuitKeys struct {
+	once sync.Once
+	ccs  constraint.ConstraintSystem
+	pk   circuit.ProvingKey
+	vk   circuit.VerifyingKey
+	err  error
+}
+
+func circuitKeyState(name string) *circuitKeys {
+	circuitStatesMu.Lock()
+	defer circuitStatesMu.Unlock()
+
+	state, ok := circuitStates[name]
+	if !ok {
+		state = &circuitKeys{}
+		circuitStates[name] = state
+	}
+	return state
+}
+
+// loadOrSetup compiles entry's circuit and loads its keys from
+// keyDir/{name}, running a fresh trusted setup (and persisting the result)
+// the first time a circuit is used.
+func loadOrSetup(entry *circuit.Entry) (constraint.ConstraintSystem, circuit.ProvingKey, circuit.VerifyingKey, error) {
+	state := circuitKeyState(entry.Name)
+	state.once.Do(func() {
+		ccs, err := circuit.Compile(entry)
+		if err != nil {
+			state.err = err
+			return
+		}
+		state.ccs = ccs
+
+		store, err := keystore.NewStore(filepath.Join(keyDir, entry.Name))
+		if err != nil {
+			state.err = err
+			return
+		}
+
+		rawPK, rawVK, found, err := store.LoadGeneric(
+			func() io.ReaderFrom { return circuit.NewProvingKey(entry) },
+			func() io.ReaderFrom { return circuit.NewVerifyingKey(entry) },
+		)
+		if err != nil {
+			state.err = err
+			return
+		}
+		if found {
+			log.Printf("🔑 Loaded keys for circuit %q from disk", entry.Name)
+			state.pk, state.vk = rawPK.(circuit.ProvingKey), rawVK.(circuit.VerifyingKey)
+			return
+		}
+
+		log.Printf("🔧 Running trusted setup for circuit %q...", entry.Name)
+		pk, vk, err := circuit.GenericSetup(entry, ccs)
+		if err != nil {
+			state.err = err
+			return
+		}
+		if err := store.SaveGeneric(pk, vk); err != nil {
+			state.err = err
+			return
+		}
+		state.pk, state.vk = pk, vk
+	})
+	return state.ccs, state.pk, state.vk, state.err
+}
+
+// ListCircuits handles GET /api/circuits
+func ListCircuits(w http.ResponseWriter, r *http.Request) {
+	respondWithJSON(w, http.StatusOK, registry.List())
+}
+
+type circuitProveRequest struct {
+	Inputs json.RawMessage `json:"inputs"`
+}
+
+type circuitProveResponse struct {
+	Proof []byte `json:"proof"`
+}
+
+// ProveCircuit handles POST /api/circuits/{name}/prove
+func ProveCircuit(w http.ResponseWriter, r *http.Request) {
+	entry, ok := lookupEntry(w, r)
+	if !ok {
+		return
+	}
+
+	var req circuitProveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	ccs, pk, _, err := loadOrSetup(entry)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Setup failed: "+err.Error())
+		return
+	}
+
+	assignment, err := entry.DecodeAssignment(req.Inputs)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid inputs: "+err.Error())
+		return
+	}
+
+	proof, err := circuit.GenericProve(entry, ccs, pk, assignment)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to generate proof: "+err.Error())
+		return
+	}
+
+	buf := new(bytes.Buffer)
+	if _, err := proof.WriteTo(buf); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to serialize proof: "+err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, circuitProveResponse{Proof: buf.Bytes()})
+}
+
+type circuitVerifyRequest struct {
+	Inputs json.RawMessage `json:"inputs"`
+	Proof  []byte          `json:"proof"`
+}
+
+// VerifyCircuit handles POST /api/circuits/{name}/verify
+func VerifyCircuit(w http.ResponseWriter, r *http.Request) {
+	entry, ok := lookupEntry(w, r)
+	if !ok {
+		return
+	}
+
+	var req circuitVerifyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	_, _, vk, err := loadOrSetup(entry)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Setup failed: "+err.Error())
+		return
+	}
+
+	publicAssignment, err := entry.DecodePublicAssignment(req.Inputs)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid inputs: "+err.Error())
+		return
+	}
+
+	proof := circuit.NewProof(entry)
+	if _, err := proof.ReadFrom(bytes.NewReader(req.Proof)); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid proof format")
+		return
+	}
+
+	if err := circuit.GenericVerify(entry, vk, proof, publicAssignment); err != nil {
+		respondWithJSON(w, http.StatusOK, map[string]interface{}{"valid": false, "message": err.Error()})
+		return
+	}
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{"valid": true})
+}
+
+func lookupEntry(w http.ResponseWriter, r *http.Request) (*circuit.Entry, bool) {
+	name := mux.Vars(r)["name"]
+	entry, ok := registry.Get(name)
+	if !ok {
+		respondWithError(w, http.StatusNotFound, "Unknown circuit: "+name)
+		return nil, false
+	}
+	return entry, true
+}
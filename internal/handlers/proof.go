@@ -4,38 +4,128 @@ package handlers
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
+	"strconv"
 	"sync"
+	"time"
+
 	"simple_zkp_integration/internal/circuit"
+	"simple_zkp_integration/internal/jobs"
+	"simple_zkp_integration/internal/keystore"
 	"simple_zkp_integration/pkg/models"
 
-	"github.com/consensys/gnark/backend/groth16"
 	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/gorilla/mux"
 )
 
 var (
-	// Global keys stored in memory (in production, these would be persisted)
+	// Global keys, loaded (or generated) once at startup and kept in memory.
 	provingKey   groth16.ProvingKey
 	verifyingKey groth16.VerifyingKey
 	setupOnce    sync.Once
 	setupErr     error
+
+	// proofPool bounds how many groth16.Prove calls run concurrently.
+	proofPool     *jobs.Pool
+	proofPoolOnce sync.Once
+
+	// keyDir and expectedVKFingerprint are set via SetKeyConfig before the
+	// server starts handling requests.
+	keyDir                = "data/keys"
+	expectedVKFingerprint string
 )
 
-// initializeKeys performs the one-time setup to generate keys
+// SetKeyConfig configures where proving/verifying keys are persisted and,
+// optionally, the verifying-key fingerprint the operator expects to find on
+// disk. It must be called before the server starts accepting requests.
+func SetKeyConfig(dir, expectedFingerprint string) {
+	keyDir = dir
+	expectedVKFingerprint = expectedFingerprint
+}
+
+// defaultPoolConcurrency is the number of worker goroutines backing
+// proofPool. In production this would come from configuration.
+const defaultPoolConcurrency = 4
+
+func getProofPool() *jobs.Pool {
+	proofPoolOnce.Do(func() {
+		proofPool = jobs.NewPool(defaultPoolConcurrency, nil)
+	})
+	return proofPool
+}
+
+// InitializeKeys loads proving/verifying keys from keyDir, only falling back
+// to a fresh trusted setup when no keys have been persisted yet. Every
+// restart therefore keeps honoring proofs issued against the previous keys
+// instead of silently invalidating them.
+//
+// It is exported and sync.Once-guarded so main() can call it synchronously
+// at boot - that's what makes the vk fingerprint get logged, and an
+// --expected-vk-fingerprint mismatch get caught, before the server starts
+// accepting traffic instead of on whichever request happens to arrive first.
+// Handlers also call it lazily, so a direct `go run` without an explicit
+// boot-time call still initializes keys on first use.
+func InitializeKeys() error {
+	initializeKeys()
+	return setupErr
+}
+
 func initializeKeys() {
 	setupOnce.Do(func() {
-		log.Println("🔧 Performing trusted setup (generating keys)...")
-		provingKey, verifyingKey, setupErr = circuit.Setup()
-		if setupErr != nil {
-			log.Printf("❌ Setup failed: %v", setupErr)
+		store, err := keystore.NewStore(keyDir)
+		if err != nil {
+			setupErr = err
+			return
+		}
+
+		pk, vk, found, err := store.Load(ecc.BN254)
+		if err != nil {
+			setupErr = err
+			return
+		}
+
+		if found {
+			log.Println("🔑 Loaded proving/verifying keys from disk")
+			provingKey, verifyingKey = pk, vk
+		} else {
+			log.Println("🔧 No persisted keys found, performing trusted setup...")
+			provingKey, verifyingKey, err = circuit.Setup()
+			if err != nil {
+				setupErr = err
+				log.Printf("❌ Setup failed: %v", err)
+				return
+			}
+			if err := store.Save(provingKey, verifyingKey); err != nil {
+				setupErr = err
+				log.Printf("❌ Failed to persist keys: %v", err)
+				return
+			}
+		}
+
+		fingerprint, err := keystore.Fingerprint(verifyingKey)
+		if err != nil {
+			setupErr = err
 			return
 		}
+		log.Printf("🔒 Verifying-key fingerprint: sha256:%s", fingerprint)
+
+		if expectedVKFingerprint != "" && fingerprint != expectedVKFingerprint {
+			setupErr = fmt.Errorf("verifying key fingerprint mismatch: expected sha256:%s, got sha256:%s", expectedVKFingerprint, fingerprint)
+			log.Printf("❌ %v", setupErr)
+			return
+		}
+
 		log.Println("✅ Setup complete! Ready to generate and verify proofs.")
 	})
 }
 
-// GenerateProof handles POST /api/proof/generate
+// GenerateProof handles POST /api/proof/generate. It enqueues a proof job on
+// the pool and returns immediately - callers poll GET /api/proof/jobs/{id}
+// (or block on the /wait variant) for the result instead of holding the
+// connection open for the full duration of groth16.Prove.
 func GenerateProof(w http.ResponseWriter, r *http.Request) {
 	// Initialize keys if not already done
 	initializeKeys()
@@ -58,36 +148,79 @@ func GenerateProof(w http.ResponseWriter, r *http.Request) {
 	// 	return
 	// }
 
-	log.Printf("📝 Generating proof for: %d + %d = %d", req.A, req.B, req.Sum)
+	job := getProofPool().Submit(provingKey, verifyingKey, req.A, req.B, req.Sum)
+	log.Printf("📝 Enqueued proof job %s for: %d + %d = %d", job.ID, req.A, req.B, req.Sum)
 
-	// Generate proof
-	proof, err := circuit.GenerateProof(provingKey, req.A, req.B, req.Sum)
-	if err != nil {
-		log.Printf("❌ Proof generation failed: %v", err)
-		respondWithError(w, http.StatusInternalServerError, "Failed to generate proof")
-		return
+	snap := job.Snapshot()
+	respondWithJSON(w, http.StatusAccepted, map[string]string{
+		"job_id": snap.ID,
+		"status": string(snap.Status),
+	})
+}
+
+// jobResponse is the JSON shape returned for a single proof job, regardless
+// of which handler is reporting it.
+type jobResponse struct {
+	JobID  string `json:"job_id"`
+	Status string `json:"status"`
+	Sum    int    `json:"sum,omitempty"`
+	Proof  []byte `json:"proof,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+func toJobResponse(job *jobs.Job) jobResponse {
+	snap := job.Snapshot()
+	return jobResponse{
+		JobID:  snap.ID,
+		Status: string(snap.Status),
+		Sum:    snap.Sum,
+		Proof:  snap.Proof,
+		Error:  snap.Err,
 	}
+}
 
-	// Serialize proof to bytes using WriteTo
-	// This is the standard way to serialize proofs in gnark
-	buf := new(bytes.Buffer)
-	if _, err := proof.WriteTo(buf); err != nil {
-		log.Printf("❌ Proof serialization failed: %v", err)
-		respondWithError(w, http.StatusInternalServerError, "Failed to serialize proof")
+// GetProofJob handles GET /api/proof/jobs/{id}
+func GetProofJob(w http.ResponseWriter, r *http.Request) {
+	job, ok := getProofPool().Get(mux.Vars(r)["id"])
+	if !ok {
+		respondWithError(w, http.StatusNotFound, "Unknown job")
 		return
 	}
-	proofBytes := buf.Bytes()
+	respondWithJSON(w, http.StatusOK, toJobResponse(job))
+}
 
-	log.Printf("✅ Proof generated successfully!")
+// CancelProofJob handles POST /api/proof/jobs/{id}/cancel
+func CancelProofJob(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	if err := getProofPool().Cancel(id); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	job, _ := getProofPool().Get(id)
+	respondWithJSON(w, http.StatusOK, toJobResponse(job))
+}
 
-	// Return response
-	response := models.ProofResponse{
-		Proof:   proofBytes,
-		Sum:     req.Sum,
-		Message: "Proof generated successfully. This proves you know two numbers that add up to the sum, without revealing the numbers themselves!",
+// WaitForProofJob handles GET /api/proof/jobs/{id}/wait?timeout=30s
+func WaitForProofJob(w http.ResponseWriter, r *http.Request) {
+	timeout := 30 * time.Second
+	if raw := r.URL.Query().Get("timeout"); raw != "" {
+		if parsed, err := time.ParseDuration(raw); err == nil {
+			timeout = parsed
+		} else if seconds, err := strconv.Atoi(raw); err == nil {
+			timeout = time.Duration(seconds) * time.Second
+		}
 	}
 
-	respondWithJSON(w, http.StatusOK, response)
+	job, err := getProofPool().Wait(mux.Vars(r)["id"], timeout)
+	if err != nil {
+		if job == nil {
+			respondWithError(w, http.StatusNotFound, "Unknown job")
+			return
+		}
+		respondWithJSON(w, http.StatusRequestTimeout, toJobResponse(job))
+		return
+	}
+	respondWithJSON(w, http.StatusOK, toJobResponse(job))
 }
 
 // VerifyProof handles POST /api/proof/verify
@@ -118,7 +251,7 @@ func VerifyProof(w http.ResponseWriter, r *http.Request) {
 
 	// Verify proof
 	err := circuit.VerifyProof(verifyingKey, proof, req.Sum)
-	
+
 	if err != nil {
 		log.Printf("❌ Proof verification failed: %v", err)
 		response := models.VerifyResponse{
@@ -139,6 +272,42 @@ func VerifyProof(w http.ResponseWriter, r *http.Request) {
 	respondWithJSON(w, http.StatusOK, response)
 }
 
+// GetVKFingerprint handles GET /api/vk/fingerprint
+func GetVKFingerprint(w http.ResponseWriter, r *http.Request) {
+	initializeKeys()
+	if setupErr != nil {
+		respondWithError(w, http.StatusInternalServerError, "Setup failed: "+setupErr.Error())
+		return
+	}
+
+	fingerprint, err := keystore.Fingerprint(verifyingKey)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fingerprint verifying key: "+err.Error())
+		return
+	}
+	respondWithJSON(w, http.StatusOK, map[string]string{"fingerprint": "sha256:" + fingerprint})
+}
+
+// GetVK handles GET /api/vk, returning the raw serialized verifying key so
+// clients and on-chain verifiers can pin the exact key they trust.
+func GetVK(w http.ResponseWriter, r *http.Request) {
+	initializeKeys()
+	if setupErr != nil {
+		respondWithError(w, http.StatusInternalServerError, "Setup failed: "+setupErr.Error())
+		return
+	}
+
+	buf := new(bytes.Buffer)
+	if _, err := verifyingKey.WriteTo(buf); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to serialize verifying key: "+err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
+
 // HealthCheck handles GET /health
 func HealthCheck(w http.ResponseWriter, r *http.Request) {
 	respondWithJSON(w, http.StatusOK, map[string]string{
@@ -156,4 +325,4 @@ func respondWithJSON(w http.ResponseWriter, status int, payload interface{}) {
 
 func respondWithError(w http.ResponseWriter, status int, message string) {
 	respondWithJSON(w, status, map[string]string{"error": message})
-}
\ No newline at end of file
+}
@@ -0,0 +1,62 @@
+// internal/handlers/solidity.go
+package handlers
+
+import (
+	"bytes"
+	"math/big"
+	"net/http"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/gorilla/mux"
+
+	"simple_zkp_integration/internal/circuit"
+)
+
+// GetVerifierSolidity handles GET /api/verifier.sol. The contract is
+// regenerated on every call from the currently loaded verifying key, so it
+// can never drift from the key the server is actually verifying proofs
+// against.
+func GetVerifierSolidity(w http.ResponseWriter, r *http.Request) {
+	initializeKeys()
+	if setupErr != nil {
+		respondWithError(w, http.StatusInternalServerError, "Setup failed: "+setupErr.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if err := circuit.ExportSolidityVerifier(verifyingKey, w); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to export Solidity verifier: "+err.Error())
+		return
+	}
+}
+
+// GetProofCalldata handles POST /api/proof/{id}/calldata, re-serializing a
+// completed proof job into the (a, b, c, input) tuple the generated
+// verifyProof function expects.
+func GetProofCalldata(w http.ResponseWriter, r *http.Request) {
+	job, ok := getProofPool().Get(mux.Vars(r)["id"])
+	if !ok {
+		respondWithError(w, http.StatusNotFound, "Unknown job")
+		return
+	}
+	snap := job.Snapshot()
+	if snap.Status != "success" {
+		respondWithError(w, http.StatusBadRequest, "Proof job has not completed successfully")
+		return
+	}
+
+	proof := groth16.NewProof(ecc.BN254)
+	if _, err := proof.ReadFrom(bytes.NewReader(snap.Proof)); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to decode stored proof: "+err.Error())
+		return
+	}
+
+	calldata, err := circuit.ProofToCalldata(proof, []*big.Int{big.NewInt(int64(snap.Sum))})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to build calldata: "+err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, calldata)
+}
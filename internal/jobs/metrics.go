@@ -0,0 +1,45 @@
+// internal/jobs/metrics.go
+package jobs
+
+import (
+	"bytes"
+
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// metricsRecorder counts how proof jobs finish so operators can see the
+// health of the pool without scraping logs.
+type metricsRecorder struct {
+	queued     prometheus.Counter
+	success    prometheus.Counter
+	failed     prometheus.Counter
+	aborted    prometheus.Counter
+	unverified prometheus.Counter
+}
+
+// jobsTotal is registered once at package init so creating multiple pools
+// (e.g. in tests) doesn't attempt to re-register the same metric.
+var jobsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "zkp_proof_jobs_total",
+	Help: "Total number of proof jobs by terminal status.",
+}, []string{"status"})
+
+func newMetricsRecorder() metricsRecorder {
+	return metricsRecorder{
+		queued:     jobsTotal.WithLabelValues("queued"),
+		success:    jobsTotal.WithLabelValues("success"),
+		failed:     jobsTotal.WithLabelValues("failed"),
+		aborted:    jobsTotal.WithLabelValues("aborted"),
+		unverified: jobsTotal.WithLabelValues("unverified"),
+	}
+}
+
+func serializeProof(proof groth16.Proof) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if _, err := proof.WriteTo(buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
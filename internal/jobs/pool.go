@@ -0,0 +1,253 @@
+// internal/jobs/pool.go
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/consensys/gnark/backend/groth16"
+
+	"simple_zkp_integration/internal/circuit"
+)
+
+// Status mirrors the terminal/non-terminal states a Hermez-style prover
+// client exposes for an in-flight proof request.
+type Status string
+
+const (
+	StatusQueued     Status = "queued"
+	StatusBusy       Status = "busy"
+	StatusSuccess    Status = "success"
+	StatusFailed     Status = "failed"
+	StatusAborted    Status = "aborted"
+	StatusUnverified Status = "unverified"
+)
+
+// Snapshot is a point-in-time, race-free copy of a Job's state, safe to read
+// and serialize after the mutex protecting the live Job has been released.
+type Snapshot struct {
+	ID        string
+	Status    Status
+	A, B, Sum int
+	Proof     []byte
+	Err       string
+}
+
+// Job is a single proof-generation request tracked by the pool. ID, A, B and
+// Sum are set once at creation and never change; status/proof/err are
+// mutated by the worker goroutine and read concurrently by HTTP handlers, so
+// they live behind mu and are only ever exposed via Snapshot.
+type Job struct {
+	ID        string
+	A, B, Sum int
+
+	mu     sync.Mutex
+	status Status
+	proof  []byte
+	err    string
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Snapshot returns a race-free copy of the job's current state.
+func (j *Job) Snapshot() Snapshot {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return Snapshot{ID: j.ID, Status: j.status, A: j.A, B: j.B, Sum: j.Sum, Proof: j.proof, Err: j.err}
+}
+
+func (j *Job) setStatus(status Status) {
+	j.mu.Lock()
+	j.status = status
+	j.mu.Unlock()
+}
+
+func (j *Job) setResult(status Status, proof []byte, err string) {
+	j.mu.Lock()
+	j.status = status
+	j.proof = proof
+	j.err = err
+	j.mu.Unlock()
+}
+
+// Store persists jobs so the in-memory implementation used here can later be
+// swapped for a Redis- or Postgres-backed one without the pool changing.
+type Store interface {
+	Create(job *Job)
+	Get(id string) (*Job, bool)
+	Update(job *Job)
+}
+
+// memStore is the default Store: jobs live only as long as the process does.
+type memStore struct {
+	mu   sync.RWMutex
+	jobs map[string]*Job
+}
+
+func newMemStore() *memStore {
+	return &memStore{jobs: make(map[string]*Job)}
+}
+
+func (s *memStore) Create(job *Job) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs[job.ID] = job
+}
+
+func (s *memStore) Get(id string) (*Job, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	job, ok := s.jobs[id]
+	return job, ok
+}
+
+func (s *memStore) Update(job *Job) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs[job.ID] = job
+}
+
+// request is the unit of work handed to a pool worker.
+type request struct {
+	job       *Job
+	ctx       context.Context
+	a, b, sum int
+	pk        groth16.ProvingKey
+	vk        groth16.VerifyingKey
+}
+
+// Pool runs proof generation on a bounded set of worker goroutines so a burst
+// of requests can't spin up unbounded concurrent Prove calls.
+type Pool struct {
+	store   Store
+	queue   chan request
+	counter uint64
+	metrics metricsRecorder
+}
+
+// NewPool starts concurrency worker goroutines backed by store. Passing a nil
+// store uses the default in-memory implementation.
+func NewPool(concurrency int, store Store) *Pool {
+	if store == nil {
+		store = newMemStore()
+	}
+	p := &Pool{
+		store:   store,
+		queue:   make(chan request, concurrency*4),
+		metrics: newMetricsRecorder(),
+	}
+	for i := 0; i < concurrency; i++ {
+		go p.worker()
+	}
+	return p
+}
+
+// Submit enqueues a proof job and returns immediately with its queued state.
+// vk is used to self-verify the proof once it's generated, so a prover bug
+// that produces a structurally valid but unsound proof surfaces as
+// StatusUnverified instead of a false StatusSuccess.
+func (p *Pool) Submit(pk groth16.ProvingKey, vk groth16.VerifyingKey, a, b, sum int) *Job {
+	id := fmt.Sprintf("job-%d", atomic.AddUint64(&p.counter, 1))
+	ctx, cancel := context.WithCancel(context.Background())
+
+	job := &Job{ID: id, A: a, B: b, Sum: sum, status: StatusQueued, cancel: cancel, done: make(chan struct{})}
+	p.store.Create(job)
+	p.metrics.queued.Inc()
+
+	p.queue <- request{job: job, ctx: ctx, a: a, b: b, sum: sum, pk: pk, vk: vk}
+	return job
+}
+
+// Get returns the current state of a job.
+func (p *Pool) Get(id string) (*Job, bool) {
+	return p.store.Get(id)
+}
+
+// Cancel aborts an in-flight or queued job by cancelling its context. It is a
+// no-op error if the job has already reached a terminal state.
+func (p *Pool) Cancel(id string) error {
+	job, ok := p.store.Get(id)
+	if !ok {
+		return fmt.Errorf("unknown job %q", id)
+	}
+	status := job.Snapshot().Status
+	if isTerminal(status) {
+		return fmt.Errorf("job %q already finished with status %q", id, status)
+	}
+	job.cancel()
+	return nil
+}
+
+// Wait blocks until job id reaches a terminal state or timeout elapses,
+// whichever comes first.
+func (p *Pool) Wait(id string, timeout time.Duration) (*Job, error) {
+	job, ok := p.store.Get(id)
+	if !ok {
+		return nil, fmt.Errorf("unknown job %q", id)
+	}
+	if isTerminal(job.Snapshot().Status) {
+		return job, nil
+	}
+
+	select {
+	case <-job.done:
+		return job, nil
+	case <-time.After(timeout):
+		return job, errors.New("timed out waiting for job to complete")
+	}
+}
+
+func (p *Pool) worker() {
+	for req := range p.queue {
+		p.run(req)
+	}
+}
+
+func (p *Pool) run(req request) {
+	job := req.job
+	defer job.cancel() // release ctx resources once the job reaches a terminal state
+
+	job.setStatus(StatusBusy)
+	p.store.Update(job)
+
+	proof, err := circuit.GenerateProofCtx(req.ctx, req.pk, req.a, req.b, req.sum)
+	switch {
+	case req.ctx.Err() != nil:
+		job.setStatus(StatusAborted)
+		p.metrics.aborted.Inc()
+	case err != nil:
+		job.setResult(StatusFailed, nil, err.Error())
+		p.metrics.failed.Inc()
+	default:
+		buf, werr := serializeProof(proof)
+		if werr != nil {
+			job.setResult(StatusFailed, nil, werr.Error())
+			p.metrics.failed.Inc()
+			break
+		}
+		if verr := circuit.VerifyProof(req.vk, proof, req.sum); verr != nil {
+			job.setResult(StatusUnverified, buf, verr.Error())
+			p.metrics.unverified.Inc()
+		} else {
+			job.setResult(StatusSuccess, buf, "")
+			p.metrics.success.Inc()
+		}
+	}
+
+	p.store.Update(job)
+	close(job.done)
+}
+
+func isTerminal(s Status) bool {
+	switch s {
+	case StatusSuccess, StatusFailed, StatusAborted, StatusUnverified:
+		return true
+	default:
+		return false
+	}
+}
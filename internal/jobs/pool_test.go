@@ -0,0 +1,98 @@
+// internal/jobs/pool_test.go
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"simple_zkp_integration/internal/circuit"
+)
+
+// TestPoolSubmitWaitSuccess checks the happy path: a valid job reaches
+// StatusSuccess with a non-empty proof once Wait returns.
+func TestPoolSubmitWaitSuccess(t *testing.T) {
+	provingKey, verifyingKey, err := circuit.Setup()
+	if err != nil {
+		t.Fatalf("circuit.Setup: %v", err)
+	}
+
+	pool := NewPool(1, nil)
+	job := pool.Submit(provingKey, verifyingKey, 2, 3, 5)
+
+	done, err := pool.Wait(job.ID, 10*time.Second)
+	if err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+
+	snap := done.Snapshot()
+	if snap.Status != StatusSuccess {
+		t.Fatalf("status = %q, want %q (err: %s)", snap.Status, StatusSuccess, snap.Err)
+	}
+	if len(snap.Proof) == 0 {
+		t.Error("proof is empty on a successful job")
+	}
+}
+
+// TestPoolCancelQueuedJob checks that cancelling a job before its worker has
+// started it lands the job in StatusAborted rather than letting it run to
+// completion. Submitting a second job right behind the first, on a
+// single-worker pool, guarantees the second job is still sitting in the
+// queue - and therefore cancellable before it starts - while the first is
+// being processed.
+func TestPoolCancelQueuedJob(t *testing.T) {
+	provingKey, verifyingKey, err := circuit.Setup()
+	if err != nil {
+		t.Fatalf("circuit.Setup: %v", err)
+	}
+
+	pool := NewPool(1, nil)
+	_ = pool.Submit(provingKey, verifyingKey, 2, 3, 5) // occupies the sole worker
+	queued := pool.Submit(provingKey, verifyingKey, 1, 1, 2)
+
+	if err := pool.Cancel(queued.ID); err != nil {
+		t.Fatalf("Cancel: %v", err)
+	}
+
+	done, err := pool.Wait(queued.ID, 10*time.Second)
+	if err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if got := done.Snapshot().Status; got != StatusAborted {
+		t.Errorf("status = %q, want %q", got, StatusAborted)
+	}
+}
+
+// TestPoolCancelAfterCompletion checks that Cancel refuses to act on a job
+// that has already reached a terminal state.
+func TestPoolCancelAfterCompletion(t *testing.T) {
+	provingKey, verifyingKey, err := circuit.Setup()
+	if err != nil {
+		t.Fatalf("circuit.Setup: %v", err)
+	}
+
+	pool := NewPool(1, nil)
+	job := pool.Submit(provingKey, verifyingKey, 2, 3, 5)
+	if _, err := pool.Wait(job.ID, 10*time.Second); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+
+	if err := pool.Cancel(job.ID); err == nil {
+		t.Error("Cancel on a finished job returned nil error, want an error")
+	}
+}
+
+// TestPoolUnknownJob checks that Cancel, Wait and Get all report a clear
+// error for a job ID the pool has never seen.
+func TestPoolUnknownJob(t *testing.T) {
+	pool := NewPool(1, nil)
+
+	if _, ok := pool.Get("no-such-job"); ok {
+		t.Error("Get found a job that was never submitted")
+	}
+	if err := pool.Cancel("no-such-job"); err == nil {
+		t.Error("Cancel on an unknown job returned nil error, want an error")
+	}
+	if _, err := pool.Wait("no-such-job", time.Second); err == nil {
+		t.Error("Wait on an unknown job returned nil error, want an error")
+	}
+}
@@ -0,0 +1,134 @@
+// internal/keystore/keystore.go
+package keystore
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/groth16"
+)
+
+const (
+	provingKeyFile   = "proving.key"
+	verifyingKeyFile = "verifying.key"
+)
+
+// Store persists a circuit's proving/verifying keys to a directory so they
+// survive process restarts instead of being regenerated - and silently
+// invalidated - on every boot.
+type Store struct {
+	dir string
+}
+
+// NewStore returns a Store rooted at dir, creating it if necessary.
+func NewStore(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating key directory: %w", err)
+	}
+	return &Store{dir: dir}, nil
+}
+
+// Load reads proving.key and verifying.key from disk as groth16 keys for
+// curveID. found is false (with a nil error) when no key material has been
+// persisted yet, which tells the caller to run a fresh setup instead.
+func (s *Store) Load(curveID ecc.ID) (pk groth16.ProvingKey, vk groth16.VerifyingKey, found bool, err error) {
+	rawPK, rawVK, found, err := s.LoadGeneric(
+		func() io.ReaderFrom { return groth16.NewProvingKey(curveID) },
+		func() io.ReaderFrom { return groth16.NewVerifyingKey(curveID) },
+	)
+	if err != nil || !found {
+		return nil, nil, found, err
+	}
+	return rawPK.(groth16.ProvingKey), rawVK.(groth16.VerifyingKey), true, nil
+}
+
+// Save atomically writes pk and vk to disk: each key is serialized to a
+// temporary file and renamed into place, so a crash mid-write never leaves a
+// truncated key behind.
+func (s *Store) Save(pk groth16.ProvingKey, vk groth16.VerifyingKey) error {
+	return s.SaveGeneric(pk, vk)
+}
+
+// LoadGeneric is the backend-agnostic form of Load: newPK/newVK construct
+// blank key values for whatever backend and curve the caller is reading
+// keys for (e.g. groth16.NewProvingKey(curveID) or
+// plonk.NewProvingKey(curveID)), so one Store implementation persists keys
+// for any backend without importing groth16 or plonk itself. found is false
+// (with a nil error) when no key material has been persisted yet.
+func (s *Store) LoadGeneric(newPK, newVK func() io.ReaderFrom) (pk, vk io.ReaderFrom, found bool, err error) {
+	pkPath := filepath.Join(s.dir, provingKeyFile)
+	vkPath := filepath.Join(s.dir, verifyingKeyFile)
+
+	if !fileExists(pkPath) || !fileExists(vkPath) {
+		return nil, nil, false, nil
+	}
+
+	pk = newPK()
+	if err := readFrom(pkPath, pk); err != nil {
+		return nil, nil, false, fmt.Errorf("reading proving key: %w", err)
+	}
+
+	vk = newVK()
+	if err := readFrom(vkPath, vk); err != nil {
+		return nil, nil, false, fmt.Errorf("reading verifying key: %w", err)
+	}
+
+	return pk, vk, true, nil
+}
+
+// SaveGeneric is the backend-agnostic form of Save.
+func (s *Store) SaveGeneric(pk, vk io.WriterTo) error {
+	if err := writeAtomic(filepath.Join(s.dir, provingKeyFile), pk); err != nil {
+		return fmt.Errorf("writing proving key: %w", err)
+	}
+	if err := writeAtomic(filepath.Join(s.dir, verifyingKeyFile), vk); err != nil {
+		return fmt.Errorf("writing verifying key: %w", err)
+	}
+	return nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func readFrom(path string, dst io.ReaderFrom) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = dst.ReadFrom(f)
+	return err
+}
+
+func writeAtomic(path string, src io.WriterTo) error {
+	buf := new(bytes.Buffer)
+	if _, err := src.WriteTo(buf); err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Fingerprint returns the SHA-256 fingerprint of the serialized verifying
+// key, formatted like the "X.509 Root Fingerprint" smallstep prints for a CA
+// root - a short, comparable value clients and on-chain verifiers can pin.
+func Fingerprint(vk groth16.VerifyingKey) (string, error) {
+	buf := new(bytes.Buffer)
+	if _, err := vk.WriteTo(buf); err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(buf.Bytes())
+	return hex.EncodeToString(sum[:]), nil
+}